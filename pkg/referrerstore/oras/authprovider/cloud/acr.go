@@ -0,0 +1,109 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider"
+)
+
+// acrRegistryPattern matches ACR hosts across public and sovereign clouds,
+// e.g. myregistry.azurecr.io, myregistry.azurecr.cn.
+var acrRegistryPattern = regexp.MustCompile(`^[a-zA-Z0-9]+\.azurecr\.(io|cn|de|us)$`)
+
+// acrRefreshTokenUsername is the fixed username ACR expects when
+// authenticating with an ACR refresh token.
+const acrRefreshTokenUsername = "00000000-0000-0000-0000-000000000000"
+
+func isACRRegistry(registry string) bool {
+	return acrRegistryPattern.MatchString(registry)
+}
+
+// acrLogin exchanges the workload's Azure AD workload identity (resolved by
+// azidentity.NewDefaultAzureCredential, which picks up AKS workload identity
+// federation) for an AAD access token, then exchanges that for an ACR refresh
+// token scoped to the target registry.
+func acrLogin(ctx context.Context, registry string) (authprovider.AuthConfig, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("failed to create Azure credential: %v", err)
+	}
+
+	aadToken, err := cred.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes: []string{"https://management.azure.com/.default"},
+	})
+	if err != nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("failed to get AAD access token: %v", err)
+	}
+
+	refreshToken, expiresOn, err := exchangeACRRefreshToken(ctx, registry, aadToken.Token)
+	if err != nil {
+		return authprovider.AuthConfig{}, err
+	}
+
+	return authprovider.AuthConfig{
+		Username:      acrRefreshTokenUsername,
+		IdentityToken: refreshToken,
+		ExpiresOn:     expiresOn,
+	}, nil
+}
+
+// exchangeACRRefreshToken calls the registry's oauth2/exchange endpoint to
+// swap an AAD access token for an ACR refresh token, per the documented
+// ACR token exchange protocol.
+func exchangeACRRefreshToken(ctx context.Context, registry, aadAccessToken string) (string, time.Time, error) {
+	form := url.Values{}
+	form.Set("grant_type", "access_token")
+	form.Set("service", registry)
+	form.Set("access_token", aadAccessToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("https://%s/oauth2/exchange", registry), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to reach ACR token exchange endpoint for %s: %v", registry, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("ACR token exchange for %s failed with status %d", registry, resp.StatusCode)
+	}
+
+	var body struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode ACR token exchange response: %v", err)
+	}
+
+	// ACR refresh tokens are valid for 3 hours; refresh a little early.
+	return body.RefreshToken, time.Now().Add(3*time.Hour - 5*time.Minute), nil
+}
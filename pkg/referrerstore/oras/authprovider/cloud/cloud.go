@@ -0,0 +1,161 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloud implements a single auth provider that auto-detects which
+// cloud a registry belongs to (ECR, GCR/Artifact Registry, ACR) and exchanges
+// the ambient cloud identity for a registry login, modeled on fluxcd's
+// pkg/oci/auth/login.
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider"
+)
+
+const providerName = "cloud"
+
+// loginFunc exchanges the ambient cloud identity for registry credentials.
+// Implementations live in ecr.go, gcr.go and acr.go.
+type loginFunc func(ctx context.Context, registry string) (authprovider.AuthConfig, error)
+
+// cloudProviderDef pairs a provider name with its host matcher and login
+// function so CloudProvider can dispatch without a type switch per call.
+type cloudProviderDef struct {
+	name    string
+	matches func(registry string) bool
+	login   loginFunc
+}
+
+var knownProviders = []cloudProviderDef{
+	{name: "aws", matches: isECRRegistry, login: ecrLogin},
+	{name: "gcp", matches: isGCRRegistry, login: gcrLogin},
+	{name: "azure", matches: isACRRegistry, login: acrLogin},
+}
+
+// CloudAuthProviderConf describes the configuration of the cloud auth provider
+type CloudAuthProviderConf struct {
+	Name      string   `json:"name"`
+	Providers []string `json:"providers,omitempty"`
+}
+
+type cloudAuthProviderFactory struct{}
+
+type cacheEntry struct {
+	authConfig authprovider.AuthConfig
+	expiresOn  time.Time
+}
+
+// CloudProvider resolves registry credentials by matching the registry host
+// against a configured list of cloud providers and exchanging the workload's
+// ambient cloud identity for a registry token.
+type CloudProvider struct {
+	providers []cloudProviderDef
+	cache     sync.Map // registry (string) -> cacheEntry
+}
+
+func init() {
+	authprovider.Register(providerName, &cloudAuthProviderFactory{})
+}
+
+// Create creates a CloudProvider enabled for the configured list of cloud
+// providers. An empty/absent `providers` list enables all known providers.
+func (f *cloudAuthProviderFactory) Create(authProviderConfig authprovider.AuthProviderConfig) (authprovider.AuthProvider, error) {
+	conf := CloudAuthProviderConf{}
+
+	authProviderConfigBytes, err := json.Marshal(authProviderConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(authProviderConfigBytes, &conf); err != nil {
+		return nil, fmt.Errorf("failed to parse cloud auth provider configuration: %v", err)
+	}
+
+	if len(conf.Providers) == 0 {
+		return &CloudProvider{providers: knownProviders}, nil
+	}
+
+	enabled := make(map[string]bool, len(conf.Providers))
+	for _, name := range conf.Providers {
+		enabled[name] = true
+	}
+
+	var providers []cloudProviderDef
+	for _, p := range knownProviders {
+		if enabled[p.name] {
+			providers = append(providers, p)
+		}
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no recognized cloud providers configured, supported values are aws, gcp, azure")
+	}
+
+	return &CloudProvider{providers: providers}, nil
+}
+
+// Enabled returns true as long as at least one cloud provider is configured;
+// individual logins fall back to anonymous when no identity is available.
+func (c *CloudProvider) Enabled(ctx context.Context) bool {
+	return len(c.providers) > 0
+}
+
+// Provide detects which configured cloud owns the artifact's registry and
+// exchanges the workload's ambient identity for a registry token, caching the
+// result under the registry host until it expires.
+func (c *CloudProvider) Provide(ctx context.Context, artifact string) (authprovider.AuthConfig, error) {
+	registry := registryHostName(artifact)
+
+	if entry, ok := c.cache.Load(registry); ok {
+		cached := entry.(cacheEntry)
+		if cached.expiresOn.IsZero() || cached.expiresOn.After(time.Now()) {
+			return cached.authConfig, nil
+		}
+	}
+
+	for _, p := range c.providers {
+		if !p.matches(registry) {
+			continue
+		}
+
+		authConfig, err := p.login(ctx, registry)
+		if err != nil {
+			logrus.Warningf("%s cloud login failed for registry %s, err: %v", p.name, registry, err)
+			logrus.Info("attempting to use anonymous credentials")
+			return authprovider.AuthConfig{}, nil
+		}
+
+		c.cache.Store(registry, cacheEntry{authConfig: authConfig, expiresOn: authConfig.ExpiresOn})
+		return authConfig, nil
+	}
+
+	// registry did not match any configured cloud; fall back to anonymous
+	return authprovider.AuthConfig{}, nil
+}
+
+func registryHostName(artifact string) string {
+	for i := 0; i < len(artifact); i++ {
+		if artifact[i] == '/' {
+			return artifact[:i]
+		}
+	}
+	return artifact
+}
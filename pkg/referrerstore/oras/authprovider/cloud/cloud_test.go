@@ -0,0 +1,91 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import "testing"
+
+func TestRegistryHostName(t *testing.T) {
+	cases := []struct {
+		artifact string
+		want     string
+	}{
+		{"myregistry.azurecr.io/repo:tag", "myregistry.azurecr.io"},
+		{"123456789012.dkr.ecr.us-west-2.amazonaws.com/repo@sha256:abc", "123456789012.dkr.ecr.us-west-2.amazonaws.com"},
+		{"gcr.io", "gcr.io"},
+		{"alpine:latest", "alpine:latest"},
+	}
+
+	for _, c := range cases {
+		if got := registryHostName(c.artifact); got != c.want {
+			t.Errorf("registryHostName(%q) = %q, want %q", c.artifact, got, c.want)
+		}
+	}
+}
+
+func TestIsECRRegistry(t *testing.T) {
+	cases := []struct {
+		registry string
+		want     bool
+	}{
+		{"123456789012.dkr.ecr.us-west-2.amazonaws.com", true},
+		{"123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn", true},
+		{"gcr.io", false},
+		{"myregistry.azurecr.io", false},
+	}
+
+	for _, c := range cases {
+		if got := isECRRegistry(c.registry); got != c.want {
+			t.Errorf("isECRRegistry(%q) = %v, want %v", c.registry, got, c.want)
+		}
+	}
+}
+
+func TestIsGCRRegistry(t *testing.T) {
+	cases := []struct {
+		registry string
+		want     bool
+	}{
+		{"gcr.io", true},
+		{"us.gcr.io", true},
+		{"us-west2-docker.pkg.dev", true},
+		{"myregistry.azurecr.io", false},
+		{"123456789012.dkr.ecr.us-west-2.amazonaws.com", false},
+	}
+
+	for _, c := range cases {
+		if got := isGCRRegistry(c.registry); got != c.want {
+			t.Errorf("isGCRRegistry(%q) = %v, want %v", c.registry, got, c.want)
+		}
+	}
+}
+
+func TestIsACRRegistry(t *testing.T) {
+	cases := []struct {
+		registry string
+		want     bool
+	}{
+		{"myregistry.azurecr.io", true},
+		{"myregistry.azurecr.cn", true},
+		{"gcr.io", false},
+		{"123456789012.dkr.ecr.us-west-2.amazonaws.com", false},
+	}
+
+	for _, c := range cases {
+		if got := isACRRegistry(c.registry); got != c.want {
+			t.Errorf("isACRRegistry(%q) = %v, want %v", c.registry, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+
+	"github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider"
+)
+
+// ecrRegistryPattern matches ECR private registry hosts, e.g.
+// 123456789012.dkr.ecr.us-west-2.amazonaws.com
+var ecrRegistryPattern = regexp.MustCompile(`^(\d{12})\.dkr\.ecr\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+func isECRRegistry(registry string) bool {
+	return ecrRegistryPattern.MatchString(registry)
+}
+
+// ecrLogin exchanges the pod's AWS identity (via IRSA/STS AssumeRoleWithWebIdentity,
+// handled transparently by the default credential chain) for an ECR authorization
+// token scoped to the account and region encoded in the registry hostname.
+func ecrLogin(ctx context.Context, registry string) (authprovider.AuthConfig, error) {
+	matches := ecrRegistryPattern.FindStringSubmatch(registry)
+	if matches == nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("%s is not a valid ECR registry", registry)
+	}
+	region := matches[2]
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("failed to load AWS config for region %s: %v", region, err)
+	}
+
+	client := ecr.NewFromConfig(cfg)
+	output, err := client.GetAuthorizationToken(ctx, &ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("failed to get ECR authorization token: %v", err)
+	}
+	if len(output.AuthorizationData) == 0 {
+		return authprovider.AuthConfig{}, fmt.Errorf("ECR returned no authorization data for registry %s", registry)
+	}
+
+	data := output.AuthorizationData[0]
+	decoded, err := base64.StdEncoding.DecodeString(*data.AuthorizationToken)
+	if err != nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("failed to decode ECR authorization token: %v", err)
+	}
+
+	userPass := strings.SplitN(string(decoded), ":", 2)
+	if len(userPass) != 2 {
+		return authprovider.AuthConfig{}, fmt.Errorf("unexpected ECR authorization token format")
+	}
+
+	expiresOn := time.Now().Add(10 * time.Minute)
+	if data.ExpiresAt != nil {
+		expiresOn = *data.ExpiresAt
+	}
+
+	return authprovider.AuthConfig{
+		Username:  userPass[0],
+		Password:  userPass[1],
+		ExpiresOn: expiresOn,
+	}, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"golang.org/x/oauth2/google"
+
+	"github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider"
+)
+
+// gcrRegistryPattern matches both the legacy GCR hosts (gcr.io, us.gcr.io, ...)
+// and regional Artifact Registry hosts (<region>-docker.pkg.dev).
+var gcrRegistryPattern = regexp.MustCompile(`^([a-z0-9-]+\.)?gcr\.io$|^[a-z0-9-]+-docker\.pkg\.dev$`)
+
+// gcrUsername is the fixed username GCR/Artifact Registry expect when
+// authenticating with an OAuth access token.
+const gcrUsername = "oauth2accesstoken"
+
+func isGCRRegistry(registry string) bool {
+	return gcrRegistryPattern.MatchString(registry)
+}
+
+// gcrLogin exchanges the workload's ambient GCP identity (GKE Workload
+// Identity or the node's attached service account, both resolved by
+// google.FindDefaultCredentials) for an access token scoped to read
+// container images from GCR/Artifact Registry.
+func gcrLogin(ctx context.Context, registry string) (authprovider.AuthConfig, error) {
+	creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/devstorage.read_only")
+	if err != nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("failed to find default GCP credentials: %v", err)
+	}
+
+	token, err := creds.TokenSource.Token()
+	if err != nil {
+		return authprovider.AuthConfig{}, fmt.Errorf("failed to get GCP access token: %v", err)
+	}
+
+	expiresOn := token.Expiry
+	if expiresOn.IsZero() {
+		expiresOn = time.Now().Add(time.Hour)
+	}
+
+	return authprovider.AuthConfig{
+		Username:  gcrUsername,
+		Password:  token.AccessToken,
+		ExpiresOn: expiresOn,
+	}, nil
+}
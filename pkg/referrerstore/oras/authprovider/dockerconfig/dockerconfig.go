@@ -0,0 +1,146 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockerconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	paths "path/filepath"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+
+	"github.com/deislabs/ratify/pkg/homedir"
+	"github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider"
+)
+
+const (
+	providerName                = "dockerConfig"
+	defaultDockerConfigFileName = "config.json"
+	dockerConfigDirEnvVar       = "DOCKER_CONFIG"
+)
+
+// DockerConfigAuthProviderConf describes the configuration of the docker config auth provider
+type DockerConfigAuthProviderConf struct {
+	Name        string   `json:"name"`
+	ConfigPaths []string `json:"configPaths,omitempty"`
+}
+
+type dockerConfigAuthProviderFactory struct{}
+
+// DockerConfigAuthProvider resolves credentials from one or more docker/podman
+// config.json files, delegating to any configured credential helpers
+// (credHelpers, credsStore) via oras-credentials-go.
+type DockerConfigAuthProvider struct {
+	configPaths []string
+	stores      []credentials.Store
+}
+
+func init() {
+	authprovider.Register(providerName, &dockerConfigAuthProviderFactory{})
+}
+
+// Create creates a DockerConfigAuthProvider from the provider configuration. If no
+// configPaths are specified, it falls back to $DOCKER_CONFIG/config.json or
+// ~/.docker/config.json, matching the docker CLI's own resolution order.
+func (f *dockerConfigAuthProviderFactory) Create(authProviderConfig authprovider.AuthProviderConfig) (authprovider.AuthProvider, error) {
+	conf := DockerConfigAuthProviderConf{}
+
+	authProviderConfigBytes, err := json.Marshal(authProviderConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(authProviderConfigBytes, &conf); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config auth provider configuration: %v", err)
+	}
+
+	configPaths := conf.ConfigPaths
+	if len(configPaths) == 0 {
+		configPaths = []string{defaultDockerConfigPath()}
+	}
+
+	stores := make([]credentials.Store, 0, len(configPaths))
+	for _, configPath := range configPaths {
+		store, err := credentials.NewStore(configPath, credentials.StoreOptions{
+			AllowPlaintextPut:        true,
+			DetectDefaultNativeStore: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load docker config file at %s: %v", configPath, err)
+		}
+		stores = append(stores, store)
+	}
+
+	return &DockerConfigAuthProvider{configPaths: configPaths, stores: stores}, nil
+}
+
+// Enabled returns true if at least one docker config file was successfully loaded
+func (d *DockerConfigAuthProvider) Enabled(ctx context.Context) bool {
+	return len(d.stores) > 0
+}
+
+// Provide looks up credentials for the given artifact's registry, walking the
+// configured docker config files in order and returning the first match. Each
+// store transparently honors that config's credsStore/credHelpers/auths fields.
+func (d *DockerConfigAuthProvider) Provide(ctx context.Context, artifact string) (authprovider.AuthConfig, error) {
+	if !d.Enabled(ctx) {
+		return authprovider.AuthConfig{}, fmt.Errorf("docker config auth provider is not properly enabled")
+	}
+
+	serverAddress := registryHostName(artifact)
+
+	for i, store := range d.stores {
+		cred, err := store.Get(ctx, serverAddress)
+		if err != nil {
+			return authprovider.AuthConfig{}, fmt.Errorf("failed to get credential for registry %s from %s: %v", serverAddress, d.configPaths[i], err)
+		}
+		if cred != auth.EmptyCredential {
+			return authprovider.AuthConfig{
+				Username:      cred.Username,
+				Password:      cred.Password,
+				IdentityToken: cred.RefreshToken,
+			}, nil
+		}
+	}
+
+	// no credential helper or auth entry matched; caller falls back to anonymous
+	return authprovider.AuthConfig{}, nil
+}
+
+// registryHostName extracts the registry host from an image reference, e.g.
+// "myregistry.azurecr.io/repo:tag" -> "myregistry.azurecr.io".
+func registryHostName(artifact string) string {
+	host := artifact
+	if idx := strings.IndexRune(host, '/'); idx != -1 {
+		host = host[:idx]
+	}
+	if host == "docker.io" {
+		// docker config files key Docker Hub under its legacy registry URL
+		return "https://index.docker.io/v1/"
+	}
+	return host
+}
+
+func defaultDockerConfigPath() string {
+	if dir := os.Getenv(dockerConfigDirEnvVar); dir != "" {
+		return paths.Join(dir, defaultDockerConfigFileName)
+	}
+	return paths.Join(homedir.Get(), ".docker", defaultDockerConfigFileName)
+}
@@ -0,0 +1,37 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dockerconfig
+
+import "testing"
+
+func TestRegistryHostName(t *testing.T) {
+	cases := []struct {
+		artifact string
+		want     string
+	}{
+		{"myregistry.azurecr.io/repo:tag", "myregistry.azurecr.io"},
+		{"myregistry.azurecr.io/nested/repo@sha256:abc", "myregistry.azurecr.io"},
+		{"docker.io/library/alpine:latest", "https://index.docker.io/v1/"},
+		{"docker.io", "https://index.docker.io/v1/"},
+		{"alpine:latest", "alpine:latest"},
+	}
+
+	for _, c := range cases {
+		if got := registryHostName(c.artifact); got != c.want {
+			t.Errorf("registryHostName(%q) = %q, want %q", c.artifact, got, c.want)
+		}
+	}
+}
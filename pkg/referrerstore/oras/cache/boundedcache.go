@@ -0,0 +1,357 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	paths "path/filepath"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	ocitarget "oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+)
+
+var indexBucket = []byte("ratify-cache-index")
+
+// indexRecord tracks what BoundedCache needs to make eviction decisions
+// without re-reading blob content: how big it is and when it was last used.
+type indexRecord struct {
+	Size       int64     `json:"size"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+// BoundedCache wraps an OCI image layout store with a small BoltDB index of
+// per-blob size and last-access time, evicting least-recently-used entries
+// once MaxBytes is exceeded and dropping entries older than TTL. A background
+// goroutine runs this compaction on a fixed interval; callers must call
+// Close to stop it.
+type BoundedCache struct {
+	root     string
+	store    *ocitarget.Store
+	db       *bbolt.DB
+	maxBytes int64
+	ttl      time.Duration
+
+	mu     sync.Mutex
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newBoundedCache(store *ocitarget.Store, root string, conf Conf) (Cache, io.Closer, error) {
+	maxBytes := conf.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+
+	var ttl time.Duration
+	if conf.TTL != "" {
+		parsed, err := time.ParseDuration(conf.TTL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cache ttl %q: %v", conf.TTL, err)
+		}
+		ttl = parsed
+	}
+
+	dbPath := paths.Join(root, "index.db")
+	db, err := bbolt.Open(dbPath, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open cache index at %s: %v", dbPath, err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(indexBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to initialize cache index: %v", err)
+	}
+
+	bc := &BoundedCache{
+		root:     root,
+		store:    store,
+		db:       db,
+		maxBytes: maxBytes,
+		ttl:      ttl,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+
+	// a blob already on disk (e.g. bounded mode was just turned on for a
+	// long-running unbounded cache) has no index entry yet; without this the
+	// eviction loop below would never see it and it would never be collected
+	if err := bc.seedIndexFromDisk(); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to seed cache index from existing blobs at %s: %v", root, err)
+	}
+
+	go bc.compactLoop()
+
+	return bc, bc, nil
+}
+
+// seedIndexFromDisk walks the blobs already present in the OCI layout and adds
+// any that are missing from the index, using the blob file's mtime as its
+// initial last-access time. Existing index entries are left untouched.
+func (b *BoundedCache) seedIndexFromDisk() error {
+	blobsRoot := paths.Join(b.root, "blobs")
+	algoDirs, err := os.ReadDir(blobsRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(indexBucket)
+		for _, algoDir := range algoDirs {
+			if !algoDir.IsDir() {
+				continue
+			}
+			algo := algoDir.Name()
+
+			digestEntries, err := os.ReadDir(paths.Join(blobsRoot, algo))
+			if err != nil {
+				continue
+			}
+			for _, digestEntry := range digestEntries {
+				if digestEntry.IsDir() {
+					continue
+				}
+
+				digestStr := algo + ":" + digestEntry.Name()
+				if bucket.Get([]byte(digestStr)) != nil {
+					continue
+				}
+
+				info, err := digestEntry.Info()
+				if err != nil {
+					continue
+				}
+
+				record := indexRecord{Size: info.Size(), LastAccess: info.ModTime()}
+				value, err := json.Marshal(record)
+				if err != nil {
+					continue
+				}
+				if err := bucket.Put([]byte(digestStr), value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (b *BoundedCache) Exists(ctx context.Context, desc oci.Descriptor) (bool, error) {
+	return b.store.Exists(ctx, desc)
+}
+
+// Fetch is serialized against Push and eviction via mu, so compact() (or a
+// direct Evict) cannot remove the blob file while Fetch is opening it. Exists
+// does not take mu: a blob evicted between a caller's Exists and its Fetch
+// makes that Fetch return a not-found error.
+func (b *BoundedCache) Fetch(ctx context.Context, desc oci.Descriptor) (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	reader, err := b.store.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	b.touch(desc)
+	return reader, nil
+}
+
+func (b *BoundedCache) Push(ctx context.Context, desc oci.Descriptor, content io.Reader) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// a blob the caller already pushed earlier (ErrAlreadyExists) still needs
+	// to land in the index, or it's untracked and can never be evicted
+	if err := b.store.Push(ctx, desc, content); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return err
+	}
+	return b.record(desc)
+}
+
+func (b *BoundedCache) Evict(ctx context.Context, desc oci.Descriptor) error {
+	return b.evict(desc)
+}
+
+// Close stops the background compaction goroutine and releases the index
+// database handle. It implements io.Closer for orasStore.Close.
+func (b *BoundedCache) Close() error {
+	close(b.stopCh)
+	<-b.doneCh
+	return b.db.Close()
+}
+
+func (b *BoundedCache) record(desc oci.Descriptor) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		record := indexRecord{Size: desc.Size, LastAccess: time.Now()}
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(indexBucket).Put([]byte(desc.Digest.String()), value)
+	})
+}
+
+func (b *BoundedCache) touch(desc oci.Descriptor) {
+	_ = b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(indexBucket)
+		raw := bucket.Get([]byte(desc.Digest.String()))
+		if raw == nil {
+			return nil
+		}
+		var record indexRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil
+		}
+		record.LastAccess = time.Now()
+		value, err := json.Marshal(record)
+		if err != nil {
+			return nil
+		}
+		return bucket.Put([]byte(desc.Digest.String()), value)
+	})
+}
+
+func (b *BoundedCache) compactLoop() {
+	defer close(b.doneCh)
+
+	ticker := time.NewTicker(defaultCompactInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ticker.C:
+			b.compact()
+		}
+	}
+}
+
+// compact drops entries older than TTL, then evicts least-recently-used
+// entries until the total tracked size is back under MaxBytes.
+func (b *BoundedCache) compact() {
+	type entry struct {
+		digest     string
+		size       int64
+		lastAccess time.Time
+	}
+
+	var entries []entry
+	var total int64
+
+	if err := b.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(indexBucket).ForEach(func(k, v []byte) error {
+			var record indexRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				return nil
+			}
+			entries = append(entries, entry{digest: string(k), size: record.Size, lastAccess: record.LastAccess})
+			total += record.Size
+			return nil
+		})
+	}); err != nil {
+		return
+	}
+
+	now := time.Now()
+	var toEvict []string
+
+	for _, e := range entries {
+		if b.ttl > 0 && now.Sub(e.lastAccess) > b.ttl {
+			toEvict = append(toEvict, e.digest)
+		}
+	}
+
+	// oldest first for LRU eviction once we're over budget. Selection sort is
+	// O(n^2), which is fine given the index is expected to stay small (bounded
+	// by MaxBytes / typical blob size); revisit if that assumption stops
+	// holding.
+	if total > b.maxBytes {
+		sortBySize := make([]entry, len(entries))
+		copy(sortBySize, entries)
+		for i := 0; i < len(sortBySize); i++ {
+			for j := i + 1; j < len(sortBySize); j++ {
+				if sortBySize[j].lastAccess.Before(sortBySize[i].lastAccess) {
+					sortBySize[i], sortBySize[j] = sortBySize[j], sortBySize[i]
+				}
+			}
+		}
+		for _, e := range sortBySize {
+			if total <= b.maxBytes {
+				break
+			}
+			toEvict = append(toEvict, e.digest)
+			total -= e.size
+		}
+	}
+
+	for _, digestStr := range toEvict {
+		b.evictByDigestString(digestStr)
+	}
+}
+
+func (b *BoundedCache) evict(desc oci.Descriptor) error {
+	return b.evictByDigestString(desc.Digest.String())
+}
+
+// evictByDigestString removes a blob from both the on-disk OCI layout and the
+// index. oras-go's OCI store has no delete API, so the blob file is removed
+// directly using the same blobs/<algorithm>/<hex> layout the store writes to.
+// It holds mu for the same reason Fetch and Push do: without it, eviction
+// could remove the blob file while a Fetch is opening it or a Push is
+// writing and indexing it.
+func (b *BoundedCache) evictByDigestString(digestStr string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	algo, hex, ok := splitDigest(digestStr)
+	if !ok {
+		return fmt.Errorf("invalid digest %q in cache index", digestStr)
+	}
+
+	blobPath := paths.Join(b.root, "blobs", algo, hex)
+	if err := os.Remove(blobPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to evict blob %s: %v", digestStr, err)
+	}
+
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(indexBucket).Delete([]byte(digestStr))
+	})
+}
+
+func splitDigest(digestStr string) (algorithm, hex string, ok bool) {
+	for i := 0; i < len(digestStr); i++ {
+		if digestStr[i] == ':' {
+			return digestStr[:i], digestStr[i+1:], true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,171 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	ocitarget "oras.land/oras-go/v2/content/oci"
+	"github.com/opencontainers/go-digest"
+)
+
+func newTestBoundedCache(t *testing.T, conf Conf) *BoundedCache {
+	t.Helper()
+
+	root := t.TempDir()
+	store, err := ocitarget.New(root)
+	if err != nil {
+		t.Fatalf("ocitarget.New failed: %v", err)
+	}
+
+	c, closer, err := newBoundedCache(store, root, conf)
+	if err != nil {
+		t.Fatalf("newBoundedCache failed: %v", err)
+	}
+	t.Cleanup(func() { closer.Close() })
+
+	return c.(*BoundedCache)
+}
+
+func TestBoundedCacheCompactEvictsOverBudgetByLRU(t *testing.T) {
+	ctx := context.Background()
+	bc := newTestBoundedCache(t, Conf{MaxBytes: 10})
+
+	d1 := digest.FromString("12345")
+	d2 := digest.FromString("67890")
+
+	if err := bc.Push(ctx, oci.Descriptor{Digest: d1, Size: 5}, bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("Push(d1) failed: %v", err)
+	}
+	if err := bc.Push(ctx, oci.Descriptor{Digest: d2, Size: 5}, bytes.NewReader([]byte("67890"))); err != nil {
+		t.Fatalf("Push(d2) failed: %v", err)
+	}
+
+	// d1+d2 = 10 bytes, at budget; a third push takes the index over budget and
+	// compact() must evict the least-recently-touched entry (d1) to get back under it
+	d3 := digest.FromString("abcde")
+	if err := bc.Push(ctx, oci.Descriptor{Digest: d3, Size: 5}, bytes.NewReader([]byte("abcde"))); err != nil {
+		t.Fatalf("Push(d3) failed: %v", err)
+	}
+
+	bc.compact()
+
+	if _, err := bc.Fetch(ctx, oci.Descriptor{Digest: d1, Size: 5}); err == nil {
+		t.Errorf("expected d1 to be evicted by compact() once over budget")
+	}
+	if _, err := bc.Fetch(ctx, oci.Descriptor{Digest: d3, Size: 5}); err != nil {
+		t.Errorf("expected most recently pushed d3 to survive compact(): %v", err)
+	}
+}
+
+func TestBoundedCacheCompactEvictsExpiredByTTL(t *testing.T) {
+	ctx := context.Background()
+	bc := newTestBoundedCache(t, Conf{TTL: "1ms"})
+
+	d1 := digest.FromString("12345")
+	if err := bc.Push(ctx, oci.Descriptor{Digest: d1, Size: 5}, bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("Push(d1) failed: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	bc.compact()
+
+	if _, err := bc.Fetch(ctx, oci.Descriptor{Digest: d1, Size: 5}); err == nil {
+		t.Errorf("expected d1 to be evicted by compact() once past its TTL")
+	}
+}
+
+func TestBoundedCacheSeedsIndexFromExistingBlobs(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	store, err := ocitarget.New(root)
+	if err != nil {
+		t.Fatalf("ocitarget.New failed: %v", err)
+	}
+
+	// simulate an unbounded cache that already has a blob on disk before bounded
+	// mode (maxBytes/ttl) is turned on
+	d1 := digest.FromString("12345")
+	if err := store.Push(ctx, oci.Descriptor{Digest: d1, Size: 5}, bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("seed Push failed: %v", err)
+	}
+
+	c, closer, err := newBoundedCache(store, root, Conf{MaxBytes: 1000})
+	if err != nil {
+		t.Fatalf("newBoundedCache failed: %v", err)
+	}
+	defer closer.Close()
+	bc := c.(*BoundedCache)
+
+	if ok, err := bc.Exists(ctx, oci.Descriptor{Digest: d1, Size: 5}); err != nil || !ok {
+		t.Fatalf("expected pre-existing blob to still be readable, ok=%v err=%v", ok, err)
+	}
+
+	// the pre-existing blob must now be tracked in the index, or it can never be evicted
+	if err := bc.evict(oci.Descriptor{Digest: d1, Size: 5}); err != nil {
+		t.Fatalf("evict(d1) failed: %v", err)
+	}
+	if ok, err := bc.Exists(ctx, oci.Descriptor{Digest: d1, Size: 5}); err != nil || ok {
+		t.Fatalf("expected seeded blob to be evictable, ok=%v err=%v", ok, err)
+	}
+}
+
+// TestBoundedCacheFetchSerializedAgainstEviction guards against the race
+// compact() (or a direct Evict) has with an in-flight Fetch of the same
+// digest: mu must keep a Fetch from ever observing the blob file removed out
+// from under it. Run with -race to get the strongest signal from this test.
+func TestBoundedCacheFetchSerializedAgainstEviction(t *testing.T) {
+	ctx := context.Background()
+	bc := newTestBoundedCache(t, Conf{MaxBytes: 1000})
+
+	desc := oci.Descriptor{
+		Digest: digest.FromString("12345"),
+		Size:   5,
+	}
+	if err := bc.Push(ctx, desc, bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = bc.evict(desc)
+			_ = bc.Push(ctx, desc, bytes.NewReader([]byte("12345")))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			// a Fetch racing the eviction/re-push loop above must either
+			// succeed or cleanly miss, never return anything else
+			if rc, err := bc.Fetch(ctx, desc); err == nil {
+				rc.Close()
+			}
+		}
+	}()
+
+	wg.Wait()
+}
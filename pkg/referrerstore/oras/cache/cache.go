@@ -0,0 +1,147 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cache defines the pluggable local content cache used by the ORAS
+// referrer store to avoid re-pulling blobs and manifests it has already seen.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	paths "path/filepath"
+	"time"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	ocitarget "oras.land/oras-go/v2/content/oci"
+
+	ratifyconfig "github.com/deislabs/ratify/config"
+	"github.com/deislabs/ratify/pkg/homedir"
+)
+
+const (
+	// TypeOCI stores content as an OCI image layout on disk (the original
+	// localCache behavior), optionally bounded by Conf.MaxBytes/Conf.TTL.
+	TypeOCI = "oci"
+	// TypeMemory keeps content in an in-memory, byte-bounded LRU. Content does
+	// not survive a process restart.
+	TypeMemory = "memory"
+	// TypeNone disables caching entirely, for stateless deployments where the
+	// local disk/memory footprint of a cache isn't worth the hit rate.
+	TypeNone = "none"
+
+	defaultLocalCachePath  = "local_oras_cache"
+	defaultMaxBytes        = 1024 * 1024 * 1024 // 1GiB
+	defaultCompactInterval = 10 * time.Minute
+)
+
+// Cache is the local store that blobs and manifests fetched from a registry
+// are written to, and read back from, on subsequent lookups.
+type Cache interface {
+	Exists(ctx context.Context, desc oci.Descriptor) (bool, error)
+	Fetch(ctx context.Context, desc oci.Descriptor) (io.ReadCloser, error)
+	Push(ctx context.Context, desc oci.Descriptor, content io.Reader) error
+	// Evict removes desc from the cache, if the underlying implementation
+	// supports targeted eviction. Implementations that don't (e.g. a plain OCI
+	// layout) return an error.
+	Evict(ctx context.Context, desc oci.Descriptor) error
+}
+
+// Conf describes the configuration of the ORAS store's local cache
+type Conf struct {
+	Type     string `json:"type,omitempty"`
+	MaxBytes int64  `json:"maxBytes,omitempty"`
+	TTL      string `json:"ttl,omitempty"`
+	// Path overrides where an "oci" cache is stored on disk. Defaults to
+	// ~/.ratify/local_oras_cache.
+	Path string `json:"path,omitempty"`
+}
+
+// New builds the configured Cache implementation. The returned closer, if
+// non-nil, must be closed when the owning store shuts down to stop any
+// background compaction and release on-disk resources.
+func New(conf Conf) (Cache, io.Closer, error) {
+	switch conf.Type {
+	case "", TypeOCI:
+		return newOCICache(conf)
+	case TypeMemory:
+		return newMemoryCache(conf), nil, nil
+	case TypeNone:
+		return noneCache{}, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported cache type %q, must be one of oci, memory, none", conf.Type)
+	}
+}
+
+func newOCICache(conf Conf) (Cache, io.Closer, error) {
+	path := conf.Path
+	if path == "" {
+		path = paths.Join(homedir.Get(), ratifyconfig.ConfigFileDir, defaultLocalCachePath)
+	}
+
+	store, err := ocitarget.New(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create local oras cache at path %s: %v", path, err)
+	}
+
+	// MaxBytes/TTL of zero means "unbounded", matching the original
+	// never-garbage-collected localCache behavior.
+	if conf.MaxBytes <= 0 && conf.TTL == "" {
+		return &ociCache{store: store}, nil, nil
+	}
+
+	return newBoundedCache(store, path, conf)
+}
+
+// ociCache adapts ocitarget.Store to the Cache interface. The underlying OCI
+// image layout store doesn't support deleting individual blobs, so Evict
+// always fails; use BoundedCache (maxBytes/ttl configured) if eviction is
+// required.
+type ociCache struct {
+	store *ocitarget.Store
+}
+
+func (c *ociCache) Exists(ctx context.Context, desc oci.Descriptor) (bool, error) {
+	return c.store.Exists(ctx, desc)
+}
+
+func (c *ociCache) Fetch(ctx context.Context, desc oci.Descriptor) (io.ReadCloser, error) {
+	return c.store.Fetch(ctx, desc)
+}
+
+func (c *ociCache) Push(ctx context.Context, desc oci.Descriptor, content io.Reader) error {
+	return c.store.Push(ctx, desc, content)
+}
+
+func (c *ociCache) Evict(ctx context.Context, desc oci.Descriptor) error {
+	return fmt.Errorf("evict is not supported by the oci cache type, configure maxBytes or ttl to enable bounded eviction")
+}
+
+// noneCache disables caching: every lookup misses, so callers always pull
+// straight from the registry.
+type noneCache struct{}
+
+func (noneCache) Exists(ctx context.Context, desc oci.Descriptor) (bool, error) { return false, nil }
+
+func (noneCache) Fetch(ctx context.Context, desc oci.Descriptor) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("cache is disabled")
+}
+
+func (noneCache) Push(ctx context.Context, desc oci.Descriptor, content io.Reader) error {
+	_, err := io.Copy(io.Discard, content)
+	return err
+}
+
+func (noneCache) Evict(ctx context.Context, desc oci.Descriptor) error { return nil }
@@ -0,0 +1,114 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// ByteLRU is a size-bounded, least-recently-used cache of byte slices, keyed
+// by string. It is the shared eviction primitive behind memoryCache (this
+// package) and the oras package's in-memory manifest cache, so the "evict
+// from the back while over budget" math only needs to be correct once.
+type ByteLRU struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type byteLRUEntry struct {
+	key   string
+	value []byte
+}
+
+// NewByteLRU returns an empty ByteLRU bounded to maxBytes total value size.
+func NewByteLRU(maxBytes int64) *ByteLRU {
+	return &ByteLRU{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Has reports whether key is present, without affecting its recency.
+func (c *ByteLRU) Has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Get returns the value for key and marks it most recently used.
+func (c *ByteLRU) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*byteLRUEntry).value, true
+}
+
+// Add inserts or updates key, marks it most recently used, then evicts
+// least-recently-used entries until the total tracked size is back under
+// maxBytes.
+func (c *ByteLRU) Add(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.order.MoveToFront(elem)
+		entry := elem.Value.(*byteLRUEntry)
+		c.curBytes += int64(len(value)) - int64(len(entry.value))
+		entry.value = value
+	} else {
+		elem := c.order.PushFront(&byteLRUEntry{key: key, value: value})
+		c.items[key] = elem
+		c.curBytes += int64(len(value))
+	}
+
+	for c.curBytes > c.maxBytes {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		entry := oldest.Value.(*byteLRUEntry)
+		c.order.Remove(oldest)
+		delete(c.items, entry.key)
+		c.curBytes -= int64(len(entry.value))
+	}
+}
+
+// Remove drops key, if present.
+func (c *ByteLRU) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return
+	}
+	entry := elem.Value.(*byteLRUEntry)
+	c.order.Remove(elem)
+	delete(c.items, key)
+	c.curBytes -= int64(len(entry.value))
+}
@@ -0,0 +1,91 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import "testing"
+
+func TestByteLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewByteLRU(10)
+
+	c.Add("a", []byte("12345"))
+	c.Add("b", []byte("12345"))
+
+	// touching "a" makes "b" the least recently used entry
+	if _, ok := c.Get("a"); !ok {
+		t.Fatalf("expected a to be cached before eviction")
+	}
+
+	// pushes curBytes to 15, over the 10 byte budget, evicting "b"
+	c.Add("c", []byte("12345"))
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("expected b to be evicted as least recently used")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("expected a to survive eviction")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Errorf("expected c to survive eviction")
+	}
+}
+
+func TestByteLRUUpdateExistingEntry(t *testing.T) {
+	c := NewByteLRU(100)
+
+	c.Add("a", []byte("first"))
+	c.Add("a", []byte("second-value"))
+
+	value, ok := c.Get("a")
+	if !ok {
+		t.Fatalf("expected a to be cached")
+	}
+	if string(value) != "second-value" {
+		t.Errorf("Get(a) = %q, want %q", value, "second-value")
+	}
+}
+
+func TestByteLRUHasDoesNotAffectRecency(t *testing.T) {
+	c := NewByteLRU(10)
+
+	c.Add("a", []byte("12345"))
+	c.Add("b", []byte("12345"))
+
+	if !c.Has("a") {
+		t.Fatalf("expected a to be present")
+	}
+
+	// "a" was only Has()-checked, not Get()-ed, so it's still the least
+	// recently used entry and should be the one evicted
+	c.Add("c", []byte("12345"))
+
+	if c.Has("a") {
+		t.Errorf("expected a to be evicted as least recently used")
+	}
+	if !c.Has("b") {
+		t.Errorf("expected b to survive eviction")
+	}
+}
+
+func TestByteLRURemove(t *testing.T) {
+	c := NewByteLRU(100)
+
+	c.Add("a", []byte("12345"))
+	c.Remove("a")
+
+	if c.Has("a") {
+		t.Errorf("expected a to be gone after Remove")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// memoryCache is an in-memory, byte-bounded LRU Cache. Content does not
+// survive a process restart, which is the point: it trades durability for a
+// zero-footprint cache in stateless deployments that still want to
+// deduplicate fetches within a single process lifetime.
+type memoryCache struct {
+	lru *ByteLRU
+}
+
+func newMemoryCache(conf Conf) *memoryCache {
+	maxBytes := conf.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxBytes
+	}
+	return &memoryCache{lru: NewByteLRU(maxBytes)}
+}
+
+func (c *memoryCache) Exists(ctx context.Context, desc oci.Descriptor) (bool, error) {
+	return c.lru.Has(desc.Digest.String()), nil
+}
+
+func (c *memoryCache) Fetch(ctx context.Context, desc oci.Descriptor) (io.ReadCloser, error) {
+	value, ok := c.lru.Get(desc.Digest.String())
+	if !ok {
+		return nil, fmt.Errorf("%s: content not found in memory cache", desc.Digest)
+	}
+	return io.NopCloser(bytes.NewReader(value)), nil
+}
+
+func (c *memoryCache) Push(ctx context.Context, desc oci.Descriptor, content io.Reader) error {
+	value, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	c.lru.Add(desc.Digest.String(), value)
+	return nil
+}
+
+func (c *memoryCache) Evict(ctx context.Context, desc oci.Descriptor) error {
+	c.lru.Remove(desc.Digest.String())
+	return nil
+}
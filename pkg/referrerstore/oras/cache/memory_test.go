@@ -0,0 +1,91 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/opencontainers/go-digest"
+)
+
+func descriptorFor(d digest.Digest, size int64) oci.Descriptor {
+	return oci.Descriptor{Digest: d, Size: size}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := newMemoryCache(Conf{MaxBytes: 10})
+
+	d1 := digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+	d2 := digest.Digest("sha256:2222222222222222222222222222222222222222222222222222222222222222")
+	d3 := digest.Digest("sha256:3333333333333333333333333333333333333333333333333333333333333333")
+
+	if err := c.Push(ctx, descriptorFor(d1, 5), bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("Push(d1) failed: %v", err)
+	}
+	if err := c.Push(ctx, descriptorFor(d2, 5), bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("Push(d2) failed: %v", err)
+	}
+
+	// touching d1 via Fetch makes d2 the least recently used entry
+	if _, err := c.Fetch(ctx, descriptorFor(d1, 5)); err != nil {
+		t.Fatalf("Fetch(d1) failed: %v", err)
+	}
+
+	// pushes curBytes to 15, over the 10 byte budget, evicting d2
+	if err := c.Push(ctx, descriptorFor(d3, 5), bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("Push(d3) failed: %v", err)
+	}
+
+	if ok, _ := c.Exists(ctx, descriptorFor(d2, 5)); ok {
+		t.Errorf("expected d2 to be evicted as least recently used")
+	}
+	if ok, _ := c.Exists(ctx, descriptorFor(d1, 5)); !ok {
+		t.Errorf("expected d1 to survive eviction")
+	}
+	if ok, _ := c.Exists(ctx, descriptorFor(d3, 5)); !ok {
+		t.Errorf("expected d3 to survive eviction")
+	}
+}
+
+func TestMemoryCacheFetchMiss(t *testing.T) {
+	ctx := context.Background()
+	c := newMemoryCache(Conf{})
+
+	d1 := digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+	if _, err := c.Fetch(ctx, descriptorFor(d1, 5)); err == nil {
+		t.Error("expected Fetch on empty cache to return an error")
+	}
+}
+
+func TestMemoryCacheEvict(t *testing.T) {
+	ctx := context.Background()
+	c := newMemoryCache(Conf{})
+
+	d1 := digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+	if err := c.Push(ctx, descriptorFor(d1, 5), bytes.NewReader([]byte("12345"))); err != nil {
+		t.Fatalf("Push(d1) failed: %v", err)
+	}
+	if err := c.Evict(ctx, descriptorFor(d1, 5)); err != nil {
+		t.Fatalf("Evict(d1) failed: %v", err)
+	}
+	if ok, _ := c.Exists(ctx, descriptorFor(d1, 5)); ok {
+		t.Errorf("expected d1 to be gone after Evict")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oras
+
+import (
+	"testing"
+
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestDedupeDescriptorsByDigestDropsDuplicates(t *testing.T) {
+	d1 := digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+	d2 := digest.Digest("sha256:2222222222222222222222222222222222222222222222222222222222222222")
+
+	input := []oci.Descriptor{
+		{Digest: d1, ArtifactType: "from-api"},
+		{Digest: d2, ArtifactType: "from-api"},
+		{Digest: d1, ArtifactType: "from-tag-schema"},
+	}
+
+	got := dedupeDescriptorsByDigest(input)
+
+	if len(got) != 2 {
+		t.Fatalf("len(got) = %d, want 2", len(got))
+	}
+	if got[0].Digest != d1 || got[0].ArtifactType != "from-api" {
+		t.Errorf("expected first occurrence of d1 to be kept, got %+v", got[0])
+	}
+	if got[1].Digest != d2 {
+		t.Errorf("expected d2 to be kept, got %+v", got[1])
+	}
+}
+
+func TestDedupeDescriptorsByDigestEmptyInput(t *testing.T) {
+	got := dedupeDescriptorsByDigest(nil)
+	if len(got) != 0 {
+		t.Errorf("len(got) = %d, want 0", len(got))
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oras
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestManifestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newManifestLRU(10)
+
+	d1 := digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+	d2 := digest.Digest("sha256:2222222222222222222222222222222222222222222222222222222222222222")
+	d3 := digest.Digest("sha256:3333333333333333333333333333333333333333333333333333333333333333")
+
+	c.add(d1, []byte("12345"))
+	c.add(d2, []byte("12345"))
+
+	// touching d1 makes d2 the least recently used entry
+	if _, ok := c.get(d1); !ok {
+		t.Fatalf("expected d1 to be cached before eviction")
+	}
+
+	// pushes curBytes to 15, over the 10 byte budget, evicting d2
+	c.add(d3, []byte("12345"))
+
+	if _, ok := c.get(d2); ok {
+		t.Errorf("expected d2 to be evicted as least recently used")
+	}
+	if _, ok := c.get(d1); !ok {
+		t.Errorf("expected d1 to survive eviction")
+	}
+	if _, ok := c.get(d3); !ok {
+		t.Errorf("expected d3 to survive eviction")
+	}
+}
+
+func TestManifestLRUUpdateExistingEntry(t *testing.T) {
+	c := newManifestLRU(100)
+
+	d1 := digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+	c.add(d1, []byte("first"))
+	c.add(d1, []byte("second-value"))
+
+	value, ok := c.get(d1)
+	if !ok {
+		t.Fatalf("expected d1 to be cached")
+	}
+	if string(value) != "second-value" {
+		t.Errorf("get(d1) = %q, want %q", value, "second-value")
+	}
+}
+
+func TestManifestLRUGetMiss(t *testing.T) {
+	c := newManifestLRU(100)
+	d1 := digest.Digest("sha256:1111111111111111111111111111111111111111111111111111111111111111")
+
+	if _, ok := c.get(d1); ok {
+		t.Errorf("expected get on empty cache to miss")
+	}
+}
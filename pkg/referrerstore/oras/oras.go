@@ -25,19 +25,19 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	paths "path/filepath"
 	"sync"
+	"syscall"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	oci "github.com/opencontainers/image-spec/specs-go/v1"
-	ocitarget "oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
 
-	ratifyconfig "github.com/deislabs/ratify/config"
 	"github.com/deislabs/ratify/pkg/common"
-	"github.com/deislabs/ratify/pkg/homedir"
 	"github.com/deislabs/ratify/pkg/ocispecs"
 	"github.com/deislabs/ratify/pkg/referrerstore"
 	"github.com/deislabs/ratify/pkg/referrerstore/config"
@@ -45,6 +45,9 @@ import (
 	"github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider"
 	_ "github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider/aws"
 	_ "github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider/azure"
+	_ "github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider/cloud"
+	_ "github.com/deislabs/ratify/pkg/referrerstore/oras/authprovider/dockerconfig"
+	"github.com/deislabs/ratify/pkg/referrerstore/oras/cache"
 	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
 )
@@ -56,12 +59,34 @@ const (
 )
 
 const (
-	storeName             = "oras"
-	defaultLocalCachePath = "local_oras_cache"
-	dockerConfigFileName  = "config.json"
-	ratifyUserAgent       = "ratify"
+	storeName            = "oras"
+	dockerConfigFileName = "config.json"
+	ratifyUserAgent      = "ratify"
+
+	// defaultManifestCacheSizeBytes bounds the in-memory manifest LRU that sits
+	// in front of localCache so hot manifests don't round-trip the on-disk store.
+	defaultManifestCacheSizeBytes = 64 * 1024 * 1024
+)
+
+// supported values for OrasStoreConf.ReferrersMode
+const (
+	referrersModeAPI  = "api"
+	referrersModeTag  = "tag"
+	referrersModeAuto = "auto"
+)
+
+// defaults for OrasStoreConf.Retry
+const (
+	defaultRetryMaxAttempts    = 5
+	defaultRetryInitialBackoff = 200 * time.Millisecond
+	defaultRetryMaxBackoff     = 30 * time.Second
 )
 
+// defaultRequestTimeout bounds a registry call when the caller didn't set a
+// deadline on its own context (e.g. context.Background() from a bare CLI
+// invocation). It never shortens a deadline the caller already set.
+const defaultRequestTimeout = 10 * time.Minute
+
 // OrasStoreConf describes the configuration of ORAS store
 type OrasStoreConf struct {
 	Name           string                          `json:"name"`
@@ -69,6 +94,30 @@ type OrasStoreConf struct {
 	CosignEnabled  bool                            `json:"cosignEnabled,omitempty"`
 	AuthProvider   authprovider.AuthProviderConfig `json:"authProvider,omitempty"`
 	LocalCachePath string                          `json:"localCachePath,omitempty"`
+	// ReferrersMode controls how ListReferrers discovers referrers: "api" uses
+	// only the OCI 1.1 Referrers API, "tag" uses only the referrers tag schema
+	// fallback, and "auto" (the default) tries the API first and falls back to
+	// the tag schema for registries that don't implement it.
+	ReferrersMode string `json:"referrersMode,omitempty"`
+	// Retry configures the backoff policy applied to registry HTTP calls that
+	// fail with a connection reset, a 5xx, or a 429 (honoring Retry-After).
+	Retry RetryConf `json:"retry,omitempty"`
+	// Cache configures the local store that fetched blobs and manifests land
+	// in. Defaults to an unbounded "oci" cache at LocalCachePath, matching the
+	// original behavior; set maxBytes/ttl to bound it, or type "none"/"memory"
+	// for stateless deployments.
+	Cache cache.Conf `json:"cache,omitempty"`
+	// RequestTimeout bounds a registry call when the caller's context carries
+	// no deadline of its own. Defaults to 10m. It is a fallback, not a
+	// replacement for a deadline the caller already set.
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+}
+
+// RetryConf describes the retry policy applied to registry HTTP calls
+type RetryConf struct {
+	MaxAttempts    int    `json:"maxAttempts,omitempty"`
+	InitialBackoff string `json:"initialBackoff,omitempty"`
+	MaxBackoff     string `json:"maxBackoff,omitempty"`
 }
 
 type orasStoreFactory struct{}
@@ -76,16 +125,56 @@ type orasStoreFactory struct{}
 type authCacheEntry struct {
 	client    *remote.Repository
 	expiresOn time.Time
+	// referrersMode records which discovery mode this registry was found to
+	// support, so later calls with ReferrersMode "auto" don't re-probe the API.
+	referrersMode string
 }
 
 type orasStore struct {
 	config             *OrasStoreConf
 	rawConfig          config.StoreConfig
-	localCache         *ocitarget.Store
+	localCache         cache.Cache
+	cacheCloser        io.Closer
+	manifestCache      *manifestLRU
 	authProvider       authprovider.AuthProvider
 	authCache          sync.Map
 	httpClient         *http.Client
 	httpClientInsecure *http.Client
+	blobFetchGroup     singleflight.Group
+	manifestFetchGroup singleflight.Group
+	requestTimeout     time.Duration
+}
+
+// withFallbackDeadline returns ctx unchanged if it already carries a
+// deadline; otherwise it returns a derived context bounded by requestTimeout,
+// so a registry call can't hang indefinitely just because the caller passed
+// in a bare context.Background().
+func (store *orasStore) withFallbackDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, store.requestTimeout)
+}
+
+// manifestLRU is a small in-memory cache, bounded by total bytes, for hot
+// manifests. It sits in front of localCache so repeated lookups of the same
+// manifest don't pay the cost of reading back from the on-disk OCI store.
+// It's a thin, digest-keyed wrapper around cache.ByteLRU, which holds the
+// actual eviction logic shared with the cache package's own in-memory cache.
+type manifestLRU struct {
+	lru *cache.ByteLRU
+}
+
+func newManifestLRU(maxBytes int64) *manifestLRU {
+	return &manifestLRU{lru: cache.NewByteLRU(maxBytes)}
+}
+
+func (c *manifestLRU) get(key digest.Digest) ([]byte, bool) {
+	return c.lru.Get(key.String())
+}
+
+func (c *manifestLRU) add(key digest.Digest, value []byte) {
+	c.lru.Add(key.String(), value)
 }
 
 func init() {
@@ -104,19 +193,29 @@ func (s *orasStoreFactory) Create(version string, storeConfig config.StorePlugin
 		return nil, fmt.Errorf("failed to parse oras store configuration: %v", err)
 	}
 
+	switch conf.ReferrersMode {
+	case "":
+		conf.ReferrersMode = referrersModeAuto
+	case referrersModeAPI, referrersModeTag, referrersModeAuto:
+	default:
+		return nil, fmt.Errorf("unsupported referrersMode %q, must be one of api, tag, auto", conf.ReferrersMode)
+	}
+
 	authenticationProvider, err := authprovider.CreateAuthProviderFromConfig(conf.AuthProvider)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth provider from configuration: %v", err)
 	}
 
-	// Set up the local cache where content will land when we pull
-	if conf.LocalCachePath == "" {
-		conf.LocalCachePath = paths.Join(homedir.Get(), ratifyconfig.ConfigFileDir, defaultLocalCachePath)
+	// Set up the local cache where content will land when we pull. LocalCachePath
+	// is kept as the default path for backwards compatibility with existing
+	// configuration; Cache.Path overrides it if both are set.
+	if conf.Cache.Path == "" {
+		conf.Cache.Path = conf.LocalCachePath
 	}
 
-	localRegistry, err := ocitarget.New(conf.LocalCachePath)
+	localCache, cacheCloser, err := cache.New(conf.Cache)
 	if err != nil {
-		return nil, fmt.Errorf("could not create local oras cache at path %s: %s", conf.LocalCachePath, err)
+		return nil, fmt.Errorf("failed to create local cache: %v", err)
 	}
 
 	// define the http Transport for TLS enabled
@@ -134,12 +233,98 @@ func (s *orasStoreFactory) Create(version string, storeConfig config.StorePlugin
 		InsecureSkipVerify: true,
 	}
 
+	retryPolicy, err := newRetryPolicy(conf.Retry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry configuration: %v", err)
+	}
+
+	secureRetryTransport := retry.NewTransport(&retryLoggingTransport{base: secureTransport})
+	secureRetryTransport.Policy = retryPolicy
+
+	insecureRetryTransport := retry.NewTransport(&retryLoggingTransport{base: insecureTransport})
+	insecureRetryTransport.Policy = retryPolicy
+
+	requestTimeout := defaultRequestTimeout
+	if conf.RequestTimeout != "" {
+		parsed, err := time.ParseDuration(conf.RequestTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid requestTimeout %q: %v", conf.RequestTimeout, err)
+		}
+		requestTimeout = parsed
+	}
+
+	// no client-level Timeout: long streaming blob fetches should only be
+	// bounded by the deadline on the caller's context, falling back to
+	// requestTimeout when the caller didn't set one of its own
 	return &orasStore{config: &conf,
 		rawConfig:          config.StoreConfig{Version: version, Store: storeConfig},
-		localCache:         localRegistry,
+		localCache:         localCache,
+		cacheCloser:        cacheCloser,
+		manifestCache:      newManifestLRU(defaultManifestCacheSizeBytes),
 		authProvider:       authenticationProvider,
-		httpClient:         &http.Client{Timeout: 10 * time.Second, Transport: secureTransport},
-		httpClientInsecure: &http.Client{Timeout: 10 * time.Second, Transport: insecureTransport}}, nil
+		httpClient:         &http.Client{Transport: secureRetryTransport},
+		httpClientInsecure: &http.Client{Transport: insecureRetryTransport},
+		requestTimeout:     requestTimeout}, nil
+}
+
+// retryLoggingTransport logs a warning whenever the underlying registry call
+// comes back with a status that retry.Transport will retry, so operators can
+// see when a registry is rate-limiting or degraded.
+type retryLoggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError) {
+		logrus.Warningf("registry %s returned status %d for %s %s, retrying with backoff", req.URL.Host, resp.StatusCode, req.Method, req.URL.Path)
+	}
+	return resp, err
+}
+
+// newRetryPolicy builds the backoff policy used by secureRetryTransport and
+// insecureRetryTransport from the user-provided RetryConf, applying defaults
+// for any unset field.
+func newRetryPolicy(conf RetryConf) (func() retry.Policy, error) {
+	maxAttempts := conf.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+
+	initialBackoff := defaultRetryInitialBackoff
+	if conf.InitialBackoff != "" {
+		parsed, err := time.ParseDuration(conf.InitialBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid initialBackoff %q: %v", conf.InitialBackoff, err)
+		}
+		initialBackoff = parsed
+	}
+
+	maxBackoff := defaultRetryMaxBackoff
+	if conf.MaxBackoff != "" {
+		parsed, err := time.ParseDuration(conf.MaxBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxBackoff %q: %v", conf.MaxBackoff, err)
+		}
+		maxBackoff = parsed
+	}
+
+	return func() retry.Policy {
+		return &retry.GenericPolicy{
+			// connection resets are retried in addition to whatever
+			// retry.DefaultPredicate retries (408, 429, 5xx)
+			Retryable: func(resp *http.Response, err error) (bool, error) {
+				if err != nil && errors.Is(err, syscall.ECONNRESET) {
+					return true, nil
+				}
+				return retry.DefaultPredicate(resp, err)
+			},
+			Backoff:  retry.ExponentialBackoff(initialBackoff, 2, 0.1),
+			MinWait:  initialBackoff,
+			MaxWait:  maxBackoff,
+			MaxRetry: maxAttempts,
+		}
+	}, nil
 }
 
 func (store *orasStore) Name() string {
@@ -150,7 +335,20 @@ func (store *orasStore) GetConfig() *config.StoreConfig {
 	return &store.rawConfig
 }
 
+// Close shuts down any background compaction goroutine the configured cache
+// started and releases its resources. It is a no-op for cache types (e.g.
+// "memory", "none", or unbounded "oci") that don't need cleanup.
+func (store *orasStore) Close() error {
+	if store.cacheCloser == nil {
+		return nil
+	}
+	return store.cacheCloser.Close()
+}
+
 func (store *orasStore) ListReferrers(ctx context.Context, subjectReference common.Reference, artifactTypes []string, nextToken string, subjectDesc *ocispecs.SubjectDescriptor) (referrerstore.ListReferrersResult, error) {
+	ctx, cancel := store.withFallbackDeadline(ctx)
+	defer cancel()
+
 	repository, expiry, err := store.createRepository(ctx, subjectReference)
 	if err != nil {
 		return referrerstore.ListReferrersResult{}, err
@@ -168,18 +366,55 @@ func (store *orasStore) ListReferrers(ctx context.Context, subjectReference comm
 		}
 	}
 
-	// find all referrers referencing subject descriptor
-	artifactTypeFilter := ""
+	// find all referrers referencing subject descriptor, using whichever
+	// discovery mode is configured (or was previously discovered to work)
 	var referrerDescriptors []oci.Descriptor
-	if err := repository.Referrers(ctx, resolvedSubjectDesc.Descriptor, artifactTypeFilter, func(referrers []oci.Descriptor) error {
-		referrerDescriptors = append(referrerDescriptors, referrers...)
-		return nil
-	}); err != nil && !errors.Is(err, errdef.ErrNotFound) {
-		store.evictAuthCache(subjectReference.Original, err)
-		return referrerstore.ListReferrersResult{}, err
+
+	mode := store.config.ReferrersMode
+	if mode == referrersModeAuto {
+		if discovered := store.getReferrersMode(subjectReference.Original); discovered != "" {
+			mode = discovered
+		}
+	}
+
+	if mode != referrersModeTag {
+		artifactTypeFilter := ""
+		apiErr := repository.Referrers(ctx, resolvedSubjectDesc.Descriptor, artifactTypeFilter, func(referrers []oci.Descriptor) error {
+			referrerDescriptors = append(referrerDescriptors, referrers...)
+			return nil
+		})
+		switch {
+		case apiErr == nil:
+			mode = referrersModeAPI
+		case errors.Is(apiErr, errdef.ErrNotFound) || errors.Is(apiErr, errdef.ErrUnsupported):
+			if store.config.ReferrersMode == referrersModeAPI {
+				// caller pinned the mode to "api"; a real registry error, don't fall back
+				store.evictAuthCache(subjectReference.Original, apiErr)
+				return referrerstore.ListReferrersResult{}, apiErr
+			}
+			// registry doesn't implement the OCI 1.1 Referrers API; fall back
+			// to the referrers tag schema
+			mode = referrersModeTag
+		default:
+			store.evictAuthCache(subjectReference.Original, apiErr)
+			return referrerstore.ListReferrersResult{}, apiErr
+		}
+	}
+
+	if mode == referrersModeTag {
+		tagReferrers, err := store.fetchReferrersViaTagSchema(ctx, repository, resolvedSubjectDesc.Descriptor)
+		if err != nil {
+			store.evictAuthCache(subjectReference.Original, err)
+			return referrerstore.ListReferrersResult{}, err
+		}
+		referrerDescriptors = dedupeDescriptorsByDigest(append(referrerDescriptors, tagReferrers...))
 	}
+
 	// add the repository client to the auth cache if all repository operations successful
 	store.addAuthCache(subjectReference.Original, repository, expiry)
+	if store.config.ReferrersMode == referrersModeAuto {
+		store.setReferrersMode(subjectReference.Original, mode)
+	}
 
 	// convert artifact descriptors to oci descriptor with artifact type
 	var referrers []ocispecs.ReferenceDescriptor
@@ -199,6 +434,9 @@ func (store *orasStore) ListReferrers(ctx context.Context, subjectReference comm
 }
 
 func (store *orasStore) GetBlobContent(ctx context.Context, subjectReference common.Reference, digest digest.Digest) ([]byte, error) {
+	ctx, cancel := store.withFallbackDeadline(ctx)
+	defer cancel()
+
 	var err error
 	repository, expiry, err := store.createRepository(ctx, subjectReference)
 	if err != nil {
@@ -218,22 +456,42 @@ func (store *orasStore) GetBlobContent(ctx context.Context, subjectReference com
 	}
 
 	if !isCached {
-		// generate the reference path with digest
-		ref := fmt.Sprintf("%s@%s", subjectReference.Path, digest)
-
-		// fetch blob content from remote repository
-		blobDesc, rc, err := repository.Blobs().FetchReference(ctx, ref)
+		// coalesce concurrent fetches of the same digest into a single pull and
+		// a single write to the local ORAS cache. Keyed by the full subject
+		// reference plus digest, not digest alone: two different repositories
+		// can share a digest (e.g.
+		// a common base layer) but require different credentials/access, so a
+		// fetch against one must never be served as the result for the other.
+		result, err := store.blobFetchGroup.Do(subjectReference.Original+"@"+digest.String(), func() (interface{}, error) {
+			// generate the reference path with digest
+			ref := fmt.Sprintf("%s@%s", subjectReference.Path, digest)
+
+			// fetch blob content from remote repository
+			blobDesc, rc, err := repository.Blobs().FetchReference(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+
+			blobBytes, err := io.ReadAll(rc)
+			if err != nil {
+				return nil, err
+			}
+
+			// push fetched content to local ORAS cache
+			if err := store.localCache.Push(ctx, blobDesc, bytes.NewReader(blobBytes)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+				return nil, err
+			}
+			return blobBytes, nil
+		})
 		if err != nil {
 			store.evictAuthCache(subjectReference.Original, err)
 			return nil, err
 		}
+		// add the repository client to the auth cache if all repository operations successful
+		store.addAuthCache(subjectReference.Original, repository, expiry)
 
-		// push fetched content to local ORAS cache
-		orasExistsExpectedError := fmt.Errorf("%s: %s: %w", blobDesc.Digest, blobDesc.MediaType, errdef.ErrAlreadyExists)
-		err = store.localCache.Push(ctx, blobDesc, rc)
-		if err != nil && err.Error() != orasExistsExpectedError.Error() {
-			return nil, err
-		}
+		return result.([]byte), nil
 	}
 	// add the repository client to the auth cache if all repository operations successful
 	store.addAuthCache(subjectReference.Original, repository, expiry)
@@ -242,44 +500,65 @@ func (store *orasStore) GetBlobContent(ctx context.Context, subjectReference com
 }
 
 func (store *orasStore) GetReferenceManifest(ctx context.Context, subjectReference common.Reference, referenceDesc ocispecs.ReferenceDescriptor) (ocispecs.ReferenceManifest, error) {
+	ctx, cancel := store.withFallbackDeadline(ctx)
+	defer cancel()
+
 	repository, expiry, err := store.createRepository(ctx, subjectReference)
 	if err != nil {
 		return ocispecs.ReferenceManifest{}, err
 	}
 	var manifestBytes []byte
-	// check if manifest exists in local ORAS cache
-	isCached, err := store.localCache.Exists(ctx, referenceDesc.Descriptor)
-	if err != nil {
-		return ocispecs.ReferenceManifest{}, err
-	}
 
-	if !isCached {
-		// fetch manifest content from repository
-		manifestReader, err := repository.Fetch(ctx, referenceDesc.Descriptor)
-		if err != nil {
-			store.evictAuthCache(subjectReference.Original, err)
-			return ocispecs.ReferenceManifest{}, err
-		}
-
-		manifestBytes, err = io.ReadAll(manifestReader)
+	// check the in-memory LRU first so hot manifests skip the on-disk store entirely
+	if cached, ok := store.manifestCache.get(referenceDesc.Descriptor.Digest); ok {
+		manifestBytes = cached
+	} else {
+		// check if manifest exists in local ORAS cache
+		isCached, err := store.localCache.Exists(ctx, referenceDesc.Descriptor)
 		if err != nil {
 			return ocispecs.ReferenceManifest{}, err
 		}
 
-		// push fetched manifest to local ORAS cache
-		orasExistsExpectedError := fmt.Errorf("%s: %s: %w", referenceDesc.Descriptor.Digest, referenceDesc.Descriptor.MediaType, errdef.ErrAlreadyExists)
-		store.localCache.Push(ctx, referenceDesc.Descriptor, bytes.NewReader(manifestBytes))
-		if err != nil && err.Error() != orasExistsExpectedError.Error() {
-			return ocispecs.ReferenceManifest{}, err
+		if !isCached {
+			// coalesce concurrent fetches of the same digest into a single pull
+			// and a single write to the local ORAS cache. Keyed by the full
+			// subject reference plus digest, not digest alone, for the same
+			// cross-repository isolation reason as blobFetchGroup above.
+			result, err := store.manifestFetchGroup.Do(subjectReference.Original+"@"+referenceDesc.Descriptor.Digest.String(), func() (interface{}, error) {
+				manifestReader, err := repository.Fetch(ctx, referenceDesc.Descriptor)
+				if err != nil {
+					return nil, err
+				}
+				defer manifestReader.Close()
+
+				manifestBytes, err := io.ReadAll(manifestReader)
+				if err != nil {
+					return nil, err
+				}
+
+				// push fetched manifest to local ORAS cache
+				if err := store.localCache.Push(ctx, referenceDesc.Descriptor, bytes.NewReader(manifestBytes)); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+					return nil, err
+				}
+
+				return manifestBytes, nil
+			})
+			if err != nil {
+				store.evictAuthCache(subjectReference.Original, err)
+				return ocispecs.ReferenceManifest{}, err
+			}
+			manifestBytes = result.([]byte)
+
+			// add the repository client to the auth cache if all repository operations successful
+			store.addAuthCache(subjectReference.Original, repository, expiry)
+		} else {
+			manifestBytes, err = store.getRawContentFromCache(ctx, referenceDesc.Descriptor)
+			if err != nil {
+				return ocispecs.ReferenceManifest{}, err
+			}
 		}
 
-		// add the repository client to the auth cache if all repository operations successful
-		store.addAuthCache(subjectReference.Original, repository, expiry)
-	} else {
-		manifestBytes, err = store.getRawContentFromCache(ctx, referenceDesc.Descriptor)
-		if err != nil {
-			return ocispecs.ReferenceManifest{}, err
-		}
+		store.manifestCache.add(referenceDesc.Descriptor.Digest, manifestBytes)
 	}
 
 	// marshal manifest bytes into reference manifest descriptor
@@ -292,6 +571,9 @@ func (store *orasStore) GetReferenceManifest(ctx context.Context, subjectReferen
 }
 
 func (store *orasStore) GetSubjectDescriptor(ctx context.Context, subjectReference common.Reference) (*ocispecs.SubjectDescriptor, error) {
+	ctx, cancel := store.withFallbackDeadline(ctx)
+	defer cancel()
+
 	repository, expiry, err := store.createRepository(ctx, subjectReference)
 	if err != nil {
 		return nil, err
@@ -392,3 +674,75 @@ func (store *orasStore) evictAuthCache(ref string, err error) {
 	store.authCache.Delete(ref)
 	// TODO: add reliable way to conditionally evict based on error code
 }
+
+// getReferrersMode returns the referrers discovery mode previously recorded
+// for ref, or "" if none has been discovered yet.
+func (store *orasStore) getReferrersMode(ref string) string {
+	if entry, ok := store.authCache.Load(ref); ok {
+		return entry.(authCacheEntry).referrersMode
+	}
+	return ""
+}
+
+// setReferrersMode records the referrers discovery mode that was found to
+// work for ref, so later "auto" calls skip straight to it instead of
+// re-probing the Referrers API.
+func (store *orasStore) setReferrersMode(ref string, mode string) {
+	entry, _ := store.authCache.Load(ref)
+	cacheEntry, _ := entry.(authCacheEntry)
+	cacheEntry.referrersMode = mode
+	store.authCache.Store(ref, cacheEntry)
+}
+
+// fetchReferrersViaTagSchema implements the referrers tag schema fallback
+// from the OCI distribution spec: resolve the tag "sha256-<hex>" (or
+// "<algorithm>-<hex>" for other digest algorithms) against the subject
+// repository, and treat the resulting image index's manifests as the
+// referrer list. This is required for registries that don't yet implement
+// the OCI 1.1 Referrers API.
+func (store *orasStore) fetchReferrersViaTagSchema(ctx context.Context, repository *remote.Repository, subjectDesc oci.Descriptor) ([]oci.Descriptor, error) {
+	referrersTag := fmt.Sprintf("%s-%s", subjectDesc.Digest.Algorithm(), subjectDesc.Digest.Encoded())
+
+	desc, err := repository.Resolve(ctx, referrersTag)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			// no referrers tag published for this subject; it simply has no referrers
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	manifestReader, err := repository.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer manifestReader.Close()
+
+	indexBytes, err := io.ReadAll(manifestReader)
+	if err != nil {
+		return nil, err
+	}
+
+	var index oci.Index
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse referrers tag schema index %s: %v", referrersTag, err)
+	}
+
+	return index.Manifests, nil
+}
+
+// dedupeDescriptorsByDigest removes duplicate descriptors, keeping the first
+// occurrence of each digest, so merging Referrers-API and tag-schema results
+// doesn't double-report a referrer that both sources returned.
+func dedupeDescriptorsByDigest(descriptors []oci.Descriptor) []oci.Descriptor {
+	seen := make(map[digest.Digest]bool, len(descriptors))
+	deduped := make([]oci.Descriptor, 0, len(descriptors))
+	for _, d := range descriptors {
+		if seen[d.Digest] {
+			continue
+		}
+		seen[d.Digest] = true
+		deduped = append(deduped, d)
+	}
+	return deduped
+}
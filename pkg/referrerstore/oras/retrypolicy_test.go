@@ -0,0 +1,69 @@
+/*
+Copyright The Ratify Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oras
+
+import (
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+func TestNewRetryPolicyDefaults(t *testing.T) {
+	policyFn, err := newRetryPolicy(RetryConf{})
+	if err != nil {
+		t.Fatalf("newRetryPolicy returned error: %v", err)
+	}
+
+	policy, ok := policyFn().(*retry.GenericPolicy)
+	if !ok {
+		t.Fatalf("expected *retry.GenericPolicy, got %T", policyFn())
+	}
+	if policy.MinWait != defaultRetryInitialBackoff {
+		t.Errorf("MinWait = %v, want %v", policy.MinWait, defaultRetryInitialBackoff)
+	}
+	if policy.MaxWait != defaultRetryMaxBackoff {
+		t.Errorf("MaxWait = %v, want %v", policy.MaxWait, defaultRetryMaxBackoff)
+	}
+	if policy.MaxRetry != defaultRetryMaxAttempts {
+		t.Errorf("MaxRetry = %v, want %v", policy.MaxRetry, defaultRetryMaxAttempts)
+	}
+}
+
+func TestNewRetryPolicyAppliesOverrides(t *testing.T) {
+	policyFn, err := newRetryPolicy(RetryConf{MaxAttempts: 2, InitialBackoff: "1s", MaxBackoff: "5s"})
+	if err != nil {
+		t.Fatalf("newRetryPolicy returned error: %v", err)
+	}
+
+	policy := policyFn().(*retry.GenericPolicy)
+	if policy.MaxRetry != 2 {
+		t.Errorf("MaxRetry = %v, want 2", policy.MaxRetry)
+	}
+}
+
+func TestNewRetryPolicyInvalidInitialBackoff(t *testing.T) {
+	_, err := newRetryPolicy(RetryConf{InitialBackoff: "not-a-duration"})
+	if err == nil {
+		t.Fatal("expected error for invalid initialBackoff, got nil")
+	}
+}
+
+func TestNewRetryPolicyInvalidMaxBackoff(t *testing.T) {
+	_, err := newRetryPolicy(RetryConf{MaxBackoff: "not-a-duration"})
+	if err == nil {
+		t.Fatal("expected error for invalid maxBackoff, got nil")
+	}
+}